@@ -10,10 +10,75 @@ import (
 	"time"
 )
 
-// Backoff implements exponential backoff with randomized wait times. It is not safe to share a
-// Backoff structure between multiple goroutines.
+// Policy is the immutable, shareable configuration of a backoff: max retries, delay Strategy,
+// MaxDelay/Jitter caps, a default Classify and a Metrics collector. Build one with NewPolicy (or
+// rely on New's defaults) and reuse it across as many goroutines as needed; call Start to begin a
+// single retry loop against it.
+type Policy struct {
+	maxRetries int
+	strategy   Strategy
+	maxDelay   time.Duration
+	jitter     time.Duration
+	classify   Classify
+	metrics    *Metrics
+}
+
+// Option configures a Policy created by NewPolicy, or a Backoff created directly by New.
+type Option func(*Policy)
+
+// WithStrategy selects the Strategy used to compute the delay before each retry. The default is
+// Exponential(1 * time.Second).
+func WithStrategy(s Strategy) Option { return func(p *Policy) { p.strategy = s } }
+
+// WithMaxDelay caps the delay a Strategy can return, regardless of how many retries have
+// happened. A zero value (the default) leaves the delay uncapped.
+func WithMaxDelay(max time.Duration) Option { return func(p *Policy) { p.maxDelay = max } }
+
+// WithJitter adds up to the given random duration on top of the Strategy's delay, to avoid many
+// callers retrying in lockstep. The default matches historical behavior (up to 1 second).
+func WithJitter(jitter time.Duration) Option { return func(p *Policy) { p.jitter = jitter } }
+
+// WithClassifier sets the Classify used by Retry/RetryValue calls against this Policy, unless
+// overridden per call with WithClassify. The default is DefaultClassify.
+func WithClassifier(c Classify) Option { return func(p *Policy) { p.classify = c } }
+
+// WithMetrics attaches a Metrics collector (see NewMetrics) so every Wait/WaitFor/Retry against
+// this Policy is reflected in the collector's scrape.
+func WithMetrics(m *Metrics) Option { return func(p *Policy) { p.metrics = m } }
+
+// NewPolicy builds an immutable Policy. Passing no maximum number of retries means infinite
+// number, in which case Start falls back to the context deadline, or a deadline of 64 seconds
+// chosen by default if the context has none either.
+func NewPolicy(retries int, opts ...Option) *Policy {
+	p := &Policy{
+		maxRetries: retries,
+		strategy:   Exponential(time.Second),
+		jitter:     maxmilli * time.Millisecond,
+		classify:   DefaultClassify,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Start begins a single retry loop against the Policy: a cheap, per-attempt Backoff value holding
+// only the mutable state (context, retry count, last delay). A Policy can be Started many times
+// concurrently, from many goroutines, without racing.
+func (p *Policy) Start(ctx context.Context) *Backoff {
+	var cancel func()
+	// if no termination is provided, better provide a reasonable default value
+	if _, ok := ctx.Deadline(); !ok && p.maxRetries == 0 {
+		ctx, cancel = context.WithTimeout(ctx, 64*time.Second)
+	}
+	return &Backoff{policy: p, ctx: ctx, cancel: cancel}
+}
+
+// Backoff tracks the mutable state of a single retry loop started from a Policy (see
+// Policy.Start). It is not safe to share a Backoff between multiple goroutines; the Policy it was
+// started from is.
 type Backoff struct {
-	MaxRetries int
+	policy *Policy
 
 	ctx    context.Context
 	cancel func()
@@ -22,22 +87,17 @@ type Backoff struct {
 	nextDelay  time.Duration
 }
 
-// New creates a Backoff object that terminates either when the context terminates (built-in
-// timeout), or when the maximum number of retries is reached. Passing no maximum number of retries
-// means infinite number, in which case the context deadline is used, or a deadline of 2 minutes is
-// chosen by default.
-func New(ctx context.Context, retries int) *Backoff {
-	var cancel func()
-	// if no termination is provided, better provide a reasonable default value
-	if _, ok := ctx.Deadline(); !ok && retries == 0 {
-		ctx, cancel = context.WithTimeout(ctx, 64*time.Second)
-	}
-	return &Backoff{MaxRetries: retries, ctx: ctx, cancel: cancel}
+// New creates a Backoff that terminates either when the context terminates (built-in timeout), or
+// when the maximum number of retries is reached. It is a convenience for
+// NewPolicy(retries, opts...).Start(ctx), for callers that don't need to share a Policy across
+// goroutines.
+func New(ctx context.Context, retries int, opts ...Option) *Backoff {
+	return NewPolicy(retries, opts...).Start(ctx)
 }
 
 // Ongoing returns true if caller should keep going
 func (b *Backoff) Ongoing() bool {
-	return b.ctx.Err() == nil && (b.MaxRetries == 0 || b.numRetries < b.MaxRetries)
+	return b.ctx.Err() == nil && (b.policy.maxRetries == 0 || b.numRetries < b.policy.maxRetries)
 }
 
 // Err returns the reason for terminating the backoff, or nil if it didn't terminate
@@ -45,7 +105,7 @@ func (b *Backoff) Err() error {
 	if b.ctx.Err() != nil {
 		return b.ctx.Err()
 	}
-	if b.MaxRetries != 0 && b.numRetries >= b.MaxRetries {
+	if b.policy.maxRetries != 0 && b.numRetries >= b.policy.maxRetries {
 		return fmt.Errorf("terminated after %d retries", b.numRetries)
 	}
 	return nil
@@ -58,12 +118,14 @@ func (b *Backoff) NumRetries() int { return b.numRetries }
 // Returns immediately if Context is terminated
 func (b *Backoff) Wait() {
 	if b.Ongoing() {
+		d := b.NextDelay()
 		select {
 		case <-b.ctx.Done():
 			if b.cancel != nil {
 				b.cancel()
 			}
-		case <-time.After(b.NextDelay()):
+		case <-time.After(d):
+			b.policy.metrics.observeWait(d)
 		}
 	}
 }
@@ -77,14 +139,18 @@ func (b *Backoff) WaitFor(d time.Duration) {
 		return
 	}
 
+	b.policy.metrics.observeRetryAfter(d)
+
 	if b.Ongoing() {
 		b.numRetries++
+		wait := d + b.jitter()
 		select {
 		case <-b.ctx.Done():
 			if b.cancel != nil {
 				b.cancel()
 			}
-		case <-time.After(d + time.Duration(rand.Intn(maxmilli))*time.Millisecond):
+		case <-time.After(wait):
+			b.policy.metrics.observeWait(wait)
 		}
 	}
 }
@@ -93,9 +159,27 @@ func (b *Backoff) WaitFor(d time.Duration) {
 // https://cloud.google.com/iot/docs/how-tos/exponential-backoff
 const maxmilli = 1000
 
+// jitter returns a random duration in [0, policy.jitter).
+func (b *Backoff) jitter() time.Duration {
+	if b.policy.jitter <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(b.policy.jitter)))
+}
+
+// NextDelay computes the delay before the next retry using the Policy's Strategy, folds in Jitter
+// (unless the Strategy already randomizes its own delay, like DecorrelatedJitter), then applies
+// MaxDelay as a ceiling over the result, and increases the retry count accordingly. MaxDelay must
+// be applied after Jitter, not before, or it wouldn't actually be a ceiling.
 func (b *Backoff) NextDelay() time.Duration {
 	b.numRetries++
-	b.nextDelay = (1<<b.numRetries)*time.Second + time.Duration(rand.Intn(maxmilli))*time.Millisecond
-
-	return b.nextDelay
+	delay := b.policy.strategy.NextDelay(b.numRetries, b.nextDelay)
+	if _, ok := b.policy.strategy.(selfJitteringStrategy); !ok {
+		delay += b.jitter()
+	}
+	if b.policy.maxDelay > 0 && delay > b.policy.maxDelay {
+		delay = b.policy.maxDelay
+	}
+	b.nextDelay = delay
+	return delay
 }
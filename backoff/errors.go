@@ -7,6 +7,9 @@ import (
 	"time"
 
 	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // ShouldRetryHTTP can be used to know if a retry is a reasonable strategy to deal with an HTTP
@@ -52,3 +55,51 @@ func ShouldRetryPostgreSQL(err error) bool {
 		return false
 	}
 }
+
+// HTTPError wraps an *http.Response so an unsuccessful call can be returned as an error and still
+// classified with ShouldRetryHTTP/RetryAfterHTTP by Retry's default Classify.
+type HTTPError struct {
+	Response *http.Response
+}
+
+func (e *HTTPError) Error() string { return "unexpected status code " + e.Response.Status }
+
+// ShouldRetryGRPC can be used to know if a retry is a reasonable strategy to deal with a gRPC
+// error. Unlike ShouldRetryHTTP it takes a plain error: a gRPC error already implements error via
+// status.FromError, so callers don't need an HTTPError-style wrapper for Retry's default Classify
+// to recognize it.
+//
+// DeadlineExceeded is treated as retryable here, matching gRPC's own default retry policies for
+// idempotent calls; it is the caller's Backoff/Retry loop, not this function, that stops retrying
+// once the caller's own context is actually out of time.
+func ShouldRetryGRPC(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+
+	switch st.Code() {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// RetryAfterGRPC scans a gRPC error's status details for a google.rpc.RetryInfo and returns its
+// RetryDelay, so the same WaitFor flow used for HTTP's Retry-After header works for gRPC clients.
+// Returns 0 if err isn't a gRPC status error or carries no RetryInfo.
+func RetryAfterGRPC(err error) time.Duration {
+	st, ok := status.FromError(err)
+	if !ok {
+		return 0
+	}
+
+	for _, detail := range st.Details() {
+		if ri, ok := detail.(*errdetails.RetryInfo); ok {
+			return ri.GetRetryDelay().AsDuration()
+		}
+	}
+
+	return 0
+}
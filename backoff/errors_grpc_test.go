@@ -0,0 +1,57 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestShouldRetryGRPC(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		code codes.Code
+		want bool
+	}{
+		{codes.Unavailable, true},
+		{codes.ResourceExhausted, true},
+		{codes.Aborted, true},
+		{codes.DeadlineExceeded, true},
+		{codes.InvalidArgument, false},
+		{codes.NotFound, false},
+		{codes.PermissionDenied, false},
+	}
+	for _, c := range cases {
+		err := status.Error(c.code, "boom")
+		if got := ShouldRetryGRPC(err); got != c.want {
+			t.Errorf("ShouldRetryGRPC(%s) = %v, want %v", c.code, got, c.want)
+		}
+	}
+
+	if ShouldRetryGRPC(nil) {
+		t.Error("ShouldRetryGRPC(nil) should be false")
+	}
+}
+
+func TestRetryAfterGRPC(t *testing.T) {
+	t.Parallel()
+
+	st, err := status.New(codes.ResourceExhausted, "slow down").WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(5 * time.Second),
+	})
+	if err != nil {
+		t.Fatalf("failed to build status: %v", err)
+	}
+
+	if got := RetryAfterGRPC(st.Err()); got != 5*time.Second {
+		t.Errorf("got %s, want 5s", got)
+	}
+
+	if got := RetryAfterGRPC(status.Error(codes.Unavailable, "no details")); got != 0 {
+		t.Errorf("got %s, want 0", got)
+	}
+}
@@ -1,27 +1,113 @@
 package backoff
 
 import (
+	"sync"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+// Metrics instruments every Backoff/Retry that shares it, so a single scrape shows real retry
+// behavior across a whole process instead of a snapshot of one struct: how many attempts
+// succeeded, were retried or gave up, how long callers actually slept, and how long servers asked
+// them to wait.
+type Metrics struct {
+	attempts   *prometheus.CounterVec
+	wait       prometheus.Histogram
+	retryAfter prometheus.Histogram
+	giveups    *prometheus.CounterVec
+}
+
 var (
-	boMaxRetries = prometheus.NewDesc("kit_backoff_retry_max", "Maximum number of retries for backoff", nil, nil)
-	boNumRetries = prometheus.NewDesc("kit_backoff_num_retries", "Number of retries in a backoff", nil, nil)
+	metricsMu     sync.Mutex
+	metricsByName = map[string]*Metrics{}
+)
+
+// waitBuckets spans 0.5s to ~68 minutes, since this package's default Strategy is an uncapped
+// Exponential(1s): prometheus.DefBuckets tops out at 10s, which would collapse most retries past
+// the second or third into the +Inf bucket.
+var waitBuckets = prometheus.ExponentialBuckets(0.5, 2, 14)
+
+// NewMetrics returns the Metrics collector for name, registering it with the default Prometheus
+// registerer the first time it is called for that name. Subsequent calls with the same name
+// return the same collector, so a Metrics can be created once (e.g. at package init) and shared by
+// every Backoff that should be counted together.
+func NewMetrics(name string) *Metrics {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+
+	if m, ok := metricsByName[name]; ok {
+		return m
+	}
+
+	m := &Metrics{
+		attempts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "kit_backoff_attempts_total",
+			Help: "Number of backoff attempts, by outcome",
+		}, []string{"outcome"}),
+		wait: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "kit_backoff_wait_seconds",
+			Help:    "Actual time spent waiting between retries",
+			Buckets: waitBuckets,
+		}),
+		retryAfter: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "kit_backoff_retry_after_seconds",
+			Help:    "Server-suggested retry delays passed to WaitFor",
+			Buckets: waitBuckets,
+		}),
+		giveups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "kit_backoff_giveups_total",
+			Help: "Number of backoff loops that gave up, by reason",
+		}, []string{"reason"}),
+	}
+
+	reg := prometheus.WrapRegistererWith(prometheus.Labels{"name": name}, prometheus.DefaultRegisterer)
+	reg.MustRegister(m.attempts, m.wait, m.retryAfter, m.giveups)
+
+	metricsByName[name] = m
+	return m
+}
+
+// outcome labels for kit_backoff_attempts_total.
+const (
+	outcomeSuccess = "success"
+	outcomeRetry   = "retry"
+	outcomeGiveup  = "giveup"
 )
 
-// use a type to wrap prometheus metrics, so that they don’t show in the API
-type exporter Backoff
+// giveup reasons for kit_backoff_giveups_total.
+const (
+	reasonContext    = "context"
+	reasonMaxRetries = "maxretries"
+	reasonPermanent  = "permanent"
+)
+
+// m is nil-safe so a Backoff without Metrics configured can call these unconditionally.
+
+func (m *Metrics) observeWait(d time.Duration) {
+	if m == nil {
+		return
+	}
+	m.wait.Observe(d.Seconds())
+}
 
-func (b exporter) Describe(ch chan<- *prometheus.Desc) {
-	prometheus.DescribeByCollect(b, ch)
+func (m *Metrics) observeRetryAfter(d time.Duration) {
+	if m == nil || d <= 0 {
+		return
+	}
+	m.retryAfter.Observe(d.Seconds())
 }
 
-func (b exporter) Collect(ch chan<- prometheus.Metric) {
-	ch <- prometheus.MustNewConstMetric(boMaxRetries, prometheus.CounterValue, float64(b.MaxRetries))
-	ch <- prometheus.MustNewConstMetric(boNumRetries, prometheus.CounterValue, float64(b.numRetries))
+func (m *Metrics) recordOutcome(outcome string) {
+	if m == nil {
+		return
+	}
+	m.attempts.WithLabelValues(outcome).Inc()
 }
 
-// Register exports a backoff so it will be scraped by Prometheus
-func Register(b Backoff, name string) {
-	prometheus.WrapRegistererWith(prometheus.Labels{"name": name}, prometheus.DefaultRegisterer).Register(exporter(b))
+func (m *Metrics) recordGiveup(reason string) {
+	if m == nil {
+		return
+	}
+	m.giveups.WithLabelValues(reason).Inc()
 }
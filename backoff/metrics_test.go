@@ -0,0 +1,48 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics(t *testing.T) {
+	m := NewMetrics("test_metrics")
+
+	if got := NewMetrics("test_metrics"); got != m {
+		t.Fatal("NewMetrics did not return the same collector for the same name")
+	}
+
+	err := Retry(New(context.Background(), 3, WithStrategy(None()), WithMetrics(m)), func() error {
+		return Recoverable(errors.New("boom"), 0)
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if got := testutil.ToFloat64(m.attempts.WithLabelValues(outcomeGiveup)); got != 1 {
+		t.Errorf("got %v giveup attempts, want 1", got)
+	}
+	if got := testutil.ToFloat64(m.giveups.WithLabelValues(reasonMaxRetries)); got != 1 {
+		t.Errorf("got %v maxretries giveups, want 1", got)
+	}
+}
+
+func TestMetricsPermanentGiveupReconciles(t *testing.T) {
+	m := NewMetrics("test_metrics_permanent")
+
+	err := Retry(New(context.Background(), 3, WithStrategy(None()), WithMetrics(m)), func() error {
+		return Permanent(errors.New("boom"))
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	giveupAttempts := testutil.ToFloat64(m.attempts.WithLabelValues(outcomeGiveup))
+	giveupReasons := testutil.ToFloat64(m.giveups.WithLabelValues(reasonPermanent))
+	if giveupAttempts != 1 || giveupReasons != 1 {
+		t.Errorf("got %v giveup attempts and %v permanent giveups, want 1 and 1 (should reconcile)", giveupAttempts, giveupReasons)
+	}
+}
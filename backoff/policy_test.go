@@ -0,0 +1,31 @@
+package backoff
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+func TestPolicyStartConcurrent(t *testing.T) {
+	t.Parallel()
+
+	policy := NewPolicy(5, WithStrategy(None()))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			retry := policy.Start(context.Background())
+			retries := 0
+			for retry.Ongoing() {
+				retry.Wait()
+				retries++
+			}
+			if retries != 5 {
+				t.Errorf("got %d retries, want 5", retries)
+			}
+		}()
+	}
+	wg.Wait()
+}
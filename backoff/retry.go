@@ -0,0 +1,187 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Classify looks at an error returned by the function passed to Retry and decides whether it is
+// worth retrying, and if so after how long (zero lets the Backoff's own Strategy decide).
+type Classify func(err error) (retry bool, retryAfter time.Duration)
+
+// DefaultClassify is used by Retry when no classifier is configured. It treats context.Canceled
+// and context.DeadlineExceeded as permanent, honors Permanent/Recoverable wrappers, and falls back
+// to ShouldRetryHTTP (for an *HTTPError), ShouldRetryGRPC (for a gRPC status error) and
+// ShouldRetryPostgreSQL (for a *pq.Error).
+func DefaultClassify(err error) (bool, time.Duration) {
+	var perm *permanentError
+	if errors.As(err, &perm) {
+		return false, 0
+	}
+
+	var rec *recoverableError
+	if errors.As(err, &rec) {
+		return true, rec.retryAfter
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false, 0
+	}
+
+	var he *HTTPError
+	if errors.As(err, &he) {
+		return ShouldRetryHTTP(he.Response), RetryAfterHTTP(he.Response)
+	}
+
+	if st, ok := status.FromError(err); ok && st.Code() != codes.OK {
+		return ShouldRetryGRPC(err), RetryAfterGRPC(err)
+	}
+
+	var pe *pq.Error
+	if errors.As(err, &pe) {
+		return ShouldRetryPostgreSQL(err), 0
+	}
+
+	return false, 0
+}
+
+// permanentError marks an error as non-retryable, regardless of what Classify would otherwise do.
+type permanentError struct{ err error }
+
+// Permanent wraps err so Retry stops immediately instead of retrying, returning err (unwrapped) to
+// the caller. Returns nil if err is nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// recoverableError marks an error as retryable after a server-suggested delay, e.g. parsed from an
+// HTTP 429 or a gRPC RetryInfo detail.
+type recoverableError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+// Recoverable wraps err so Retry retries it after waiting retryAfter, instead of consulting
+// Classify. A zero retryAfter leaves the delay to the Backoff's own Strategy. Returns nil if err is
+// nil.
+func Recoverable(err error, retryAfter time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &recoverableError{err: err, retryAfter: retryAfter}
+}
+
+func (e *recoverableError) Error() string { return e.err.Error() }
+func (e *recoverableError) Unwrap() error { return e.err }
+
+// unwrapCause strips a Permanent/Recoverable wrapper so the caller only ever sees the underlying
+// error, never Retry's internal bookkeeping.
+func unwrapCause(err error) error {
+	var perm *permanentError
+	if errors.As(err, &perm) {
+		return perm.err
+	}
+	var rec *recoverableError
+	if errors.As(err, &rec) {
+		return rec.err
+	}
+	return err
+}
+
+// RetryOption configures a single Retry or RetryValue call.
+type RetryOption func(*retryConfig)
+
+type retryConfig struct {
+	classify Classify
+}
+
+// WithClassify overrides the Classify function Retry uses to decide whether an error is
+// retryable, taking precedence over the Policy's own WithClassifier for this call only.
+func WithClassify(c Classify) RetryOption {
+	return func(cfg *retryConfig) { cfg.classify = c }
+}
+
+// Retry calls fn until it succeeds, returns a permanent error, or b is exhausted (context done or
+// MaxRetries reached), sleeping between attempts according to b. It hides the
+// "for retry.Ongoing() { ... retry.Wait() }" boilerplate, and understands Permanent and
+// Recoverable so fn can signal whether a failure is worth retrying without fn needing to know
+// about Backoff at all. Retry drives the loop off the context b was started with (see
+// Policy.Start/New) rather than taking one of its own, so there's no second context to keep in
+// sync with b's.
+func Retry(b *Backoff, fn func() error, opts ...RetryOption) error {
+	cfg := retryConfig{classify: b.policy.classify}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var lastErr error
+	for b.Ongoing() {
+		err := fn()
+		if err == nil {
+			b.policy.metrics.recordOutcome(outcomeSuccess)
+			return nil
+		}
+
+		var (
+			retry      bool
+			retryAfter time.Duration
+			perm       *permanentError
+			rec        *recoverableError
+		)
+		switch {
+		case errors.As(err, &perm):
+		case errors.As(err, &rec):
+			retry, retryAfter = true, rec.retryAfter
+		default:
+			retry, retryAfter = cfg.classify(err)
+		}
+		lastErr = unwrapCause(err)
+		if !retry {
+			b.policy.metrics.recordOutcome(outcomeGiveup)
+			b.policy.metrics.recordGiveup(reasonPermanent)
+			return lastErr
+		}
+
+		b.WaitFor(retryAfter)
+		// an attempt after which b is exhausted is counted once, as a giveup, below
+		if b.Ongoing() {
+			b.policy.metrics.recordOutcome(outcomeRetry)
+		}
+	}
+
+	b.policy.metrics.recordOutcome(outcomeGiveup)
+	if errors.Is(b.Err(), context.Canceled) || errors.Is(b.Err(), context.DeadlineExceeded) {
+		b.policy.metrics.recordGiveup(reasonContext)
+	} else {
+		b.policy.metrics.recordGiveup(reasonMaxRetries)
+	}
+
+	if lastErr != nil {
+		return lastErr
+	}
+	return b.Err()
+}
+
+// RetryValue is like Retry, but for a function that also produces a value on success.
+func RetryValue[T any](b *Backoff, fn func() (T, error), opts ...RetryOption) (T, error) {
+	var result T
+	err := Retry(b, func() error {
+		v, err := fn()
+		if err == nil {
+			result = v
+		}
+		return err
+	}, opts...)
+	return result, err
+}
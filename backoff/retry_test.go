@@ -0,0 +1,77 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("succeeds without retrying", func(t *testing.T) {
+		t.Parallel()
+		calls := 0
+		err := Retry(New(context.Background(), 3), func() error {
+			calls++
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("got %d calls, want 1", calls)
+		}
+	})
+
+	t.Run("stops on permanent error", func(t *testing.T) {
+		t.Parallel()
+		boom := errors.New("boom")
+		calls := 0
+		err := Retry(New(context.Background(), 3, WithStrategy(None())), func() error {
+			calls++
+			return Permanent(boom)
+		})
+		if !errors.Is(err, boom) {
+			t.Errorf("got %v, want %v", err, boom)
+		}
+		if calls != 1 {
+			t.Errorf("got %d calls, want 1", calls)
+		}
+	})
+
+	t.Run("retries recoverable errors until exhausted", func(t *testing.T) {
+		t.Parallel()
+		boom := errors.New("boom")
+		calls := 0
+		err := Retry(New(context.Background(), 3, WithStrategy(None())), func() error {
+			calls++
+			return Recoverable(boom, 0)
+		})
+		if !errors.Is(err, boom) {
+			t.Errorf("got %v, want %v", err, boom)
+		}
+		if calls != 3 {
+			t.Errorf("got %d calls, want 3", calls)
+		}
+	})
+}
+
+func TestRetryValue(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	v, err := RetryValue(New(context.Background(), 3, WithStrategy(None())), func() (int, error) {
+		calls++
+		if calls < 2 {
+			return 0, Recoverable(errors.New("not yet"), 0)
+		}
+		return 42, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v != 42 {
+		t.Errorf("got %d, want 42", v)
+	}
+}
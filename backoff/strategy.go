@@ -0,0 +1,84 @@
+package backoff
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Strategy computes the base delay before the next retry. tries is the 1-based retry count (as
+// returned by NumRetries after the attempt is counted) and prev is the delay Backoff.NextDelay
+// returned for the previous retry, after Jitter and MaxDelay (zero on the first call), which
+// DecorrelatedJitter needs to compute its next value. Strategies should not add their own jitter; Backoff applies Jitter uniformly on top of
+// whatever the Strategy returns, unless the Strategy also implements selfJitteringStrategy.
+type Strategy interface {
+	NextDelay(tries int, prev time.Duration) time.Duration
+}
+
+// selfJitteringStrategy is implemented by strategies whose NextDelay is already randomized (e.g.
+// DecorrelatedJitter), so Backoff.NextDelay skips its own extra Jitter layer for them — otherwise
+// a strategy's own stated bounds (like DecorrelatedJitter's Max) would be exceeded.
+type selfJitteringStrategy interface {
+	selfJitters()
+}
+
+// None adds no delay of its own: a Backoff using it waits only for the Policy's Jitter (up to 1
+// second by default, none with WithJitter(0)). Useful for policies that only care about
+// MaxRetries/Classify, e.g. in tests.
+func None() Strategy { return noneStrategy{} }
+
+type noneStrategy struct{}
+
+func (noneStrategy) NextDelay(int, time.Duration) time.Duration { return 0 }
+
+// Constant waits the same period before every retry.
+func Constant(period time.Duration) Strategy { return constantStrategy{period} }
+
+type constantStrategy struct{ period time.Duration }
+
+func (s constantStrategy) NextDelay(int, time.Duration) time.Duration { return s.period }
+
+// Linear waits period*tries before each retry, growing by a fixed increment every time.
+func Linear(period time.Duration) Strategy { return linearStrategy{period} }
+
+type linearStrategy struct{ period time.Duration }
+
+func (s linearStrategy) NextDelay(tries int, _ time.Duration) time.Duration {
+	return s.period * time.Duration(tries)
+}
+
+// Exponential waits period*2^tries before each retry. Pair it with WithMaxDelay to cap the growth,
+// since left uncapped it explodes quickly (retry 10 is already period*1024).
+func Exponential(period time.Duration) Strategy { return exponentialStrategy{period} }
+
+type exponentialStrategy struct{ period time.Duration }
+
+func (s exponentialStrategy) NextDelay(tries int, _ time.Duration) time.Duration {
+	return s.period * (1 << tries)
+}
+
+// DecorrelatedJitter implements the AWS "decorrelated jitter" variant: sleep = min(max, random(min,
+// prev*3)). It spreads out retries from many concurrent callers better than Exponential, at the
+// cost of being less predictable. See
+// https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/.
+func DecorrelatedJitter(min, max time.Duration) Strategy {
+	return &decorrelatedJitterStrategy{min: min, max: max}
+}
+
+type decorrelatedJitterStrategy struct {
+	min, max time.Duration
+}
+
+func (s *decorrelatedJitterStrategy) NextDelay(_ int, prev time.Duration) time.Duration {
+	upper := prev * 3
+	if upper <= s.min {
+		upper = s.min + 1
+	}
+	delay := s.min + time.Duration(rand.Int63n(int64(upper-s.min)))
+	if delay > s.max {
+		delay = s.max
+	}
+	return delay
+}
+
+// selfJitters marks decorrelatedJitterStrategy as already randomized, see selfJitteringStrategy.
+func (*decorrelatedJitterStrategy) selfJitters() {}
@@ -0,0 +1,83 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNoneStrategy(t *testing.T) {
+	t.Parallel()
+	s := None()
+	for tries := 1; tries <= 3; tries++ {
+		if got := s.NextDelay(tries, 0); got != 0 {
+			t.Errorf("NextDelay(%d, 0) = %s, want 0", tries, got)
+		}
+	}
+}
+
+func TestConstantStrategy(t *testing.T) {
+	t.Parallel()
+	s := Constant(2 * time.Second)
+	for tries := 1; tries <= 5; tries++ {
+		if got := s.NextDelay(tries, 0); got != 2*time.Second {
+			t.Errorf("NextDelay(%d, 0) = %s, want 2s", tries, got)
+		}
+	}
+}
+
+func TestLinearStrategy(t *testing.T) {
+	t.Parallel()
+	s := Linear(time.Second)
+	cases := []struct {
+		tries int
+		want  time.Duration
+	}{
+		{1, 1 * time.Second},
+		{2, 2 * time.Second},
+		{5, 5 * time.Second},
+	}
+	for _, c := range cases {
+		if got := s.NextDelay(c.tries, 0); got != c.want {
+			t.Errorf("NextDelay(%d, 0) = %s, want %s", c.tries, got, c.want)
+		}
+	}
+}
+
+func TestExponentialStrategy(t *testing.T) {
+	t.Parallel()
+	s := Exponential(time.Second)
+	cases := []struct {
+		tries int
+		want  time.Duration
+	}{
+		{1, 2 * time.Second},
+		{2, 4 * time.Second},
+		{3, 8 * time.Second},
+		{10, 1024 * time.Second},
+	}
+	for _, c := range cases {
+		if got := s.NextDelay(c.tries, 0); got != c.want {
+			t.Errorf("NextDelay(%d, 0) = %s, want %s", c.tries, got, c.want)
+		}
+	}
+}
+
+func TestDecorrelatedJitterStrategy(t *testing.T) {
+	t.Parallel()
+
+	min, max := 100*time.Millisecond, time.Second
+	s := DecorrelatedJitter(min, max)
+
+	prev := time.Duration(0)
+	for i := 0; i < 1000; i++ {
+		got := s.NextDelay(i+1, prev)
+		if got < min || got > max {
+			t.Fatalf("NextDelay(%d, %s) = %s, want within [%s, %s]", i+1, prev, got, min, max)
+		}
+		prev = got
+	}
+
+	if _, ok := s.(selfJitteringStrategy); !ok {
+		t.Error("DecorrelatedJitter should implement selfJitteringStrategy")
+	}
+}